@@ -24,10 +24,30 @@ type Check struct {
 	V        *big.Int
 	R        *big.Int
 	S        *big.Int
+	// ChainID is appended after the legacy 8-element list rather than
+	// interleaved with it, and tagged optional rather than nil, so a
+	// pre-existing 8-field check still decodes correctly (with ChainID
+	// left nil) instead of every field after Lock shifting by one.
+	ChainID *big.Int `rlp:"optional"`
 }
 
+// Sender recovers the address that signed the check. Checks carrying a
+// ChainID are verified with a MinterSigner bound to that chain ID, so a
+// check cannot be replayed on a different chain; checks without one (signed
+// before the chain-ID upgrade) fall back to the legacy HomesteadSigner.
 func (check *Check) Sender() (types.Address, error) {
-	return recoverPlain(check.Hash(), check.R, check.S, check.V)
+	return signerFor(check).Sender(check)
+}
+
+// signerFor picks the signer matching the scheme the check was signed with.
+// It dispatches on ChainID rather than V, since V does not exist yet at
+// signing time (Hash is computed before the check is signed).
+func signerFor(check *Check) Signer {
+	if check.ChainID != nil {
+		return NewMinterSigner(check.ChainID)
+	}
+
+	return HomesteadSigner{}
 }
 
 func (check *Check) LockPubKey() ([]byte, error) {
@@ -51,13 +71,7 @@ func (check *Check) LockPubKey() ([]byte, error) {
 }
 
 func (check *Check) Hash() types.Hash {
-	return rlpHash([]interface{}{
-		check.Nonce,
-		check.DueBlock,
-		check.Coin,
-		check.Value,
-		check.Lock,
-	})
+	return signerFor(check).Hash(check)
 }
 
 func (check *Check) String() string {
@@ -66,6 +80,10 @@ func (check *Check) String() string {
 	return fmt.Sprintf("Check sender: %s nonce: %d, dueBlock: %d, value: %d %s", sender.String(), check.Nonce, check.DueBlock, check.Value, check.Coin.String())
 }
 
+// DecodeFromBytes decodes a Check, accepting both legacy (no ChainID) and
+// chain-ID-protected checks. The RLP decoder leaves ChainID nil for legacy
+// checks since the field is absent from the wire format, which is what
+// Sender uses to pick the matching signer.
 func DecodeFromBytes(buf []byte) (*Check, error) {
 
 	var check Check
@@ -85,11 +103,21 @@ func rlpHash(x interface{}) (h types.Hash) {
 	return h
 }
 
-func recoverPlain(sighash types.Hash, R, S, Vb *big.Int) (types.Address, error) {
+// recoverPlain recovers the sender address from a signature. When protected
+// is true, Vb is expected to already be normalized to a raw recovery ID
+// (0 or 1), as performed by MinterSigner.Sender.
+func recoverPlain(sighash types.Hash, R, S, Vb *big.Int, protected bool) (types.Address, error) {
 	if Vb.BitLen() > 8 {
 		return types.Address{}, ErrInvalidSig
 	}
-	V := byte(Vb.Uint64() - 27)
+
+	var V byte
+	if protected {
+		V = byte(Vb.Uint64())
+	} else {
+		V = byte(Vb.Uint64() - 27)
+	}
+
 	if !crypto.ValidateSignatureValues(V, R, S) {
 		return types.Address{}, ErrInvalidSig
 	}
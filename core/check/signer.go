@@ -0,0 +1,137 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/core/types"
+)
+
+// Signer encapsulates check signing and sender-recovery logic for a given
+// signature scheme. It exists so that chain-ID-aware (replay protected)
+// checks and legacy (pre-chain-ID) checks can share the same decode/verify
+// paths without branching all over the codebase.
+type Signer interface {
+	// Hash returns the hash to be signed by this signer.
+	Hash(check *Check) types.Hash
+
+	// Sender returns the sender address of the check.
+	Sender(check *Check) (types.Address, error)
+
+	// SignatureValues returns the raw R, S, V values corresponding to the
+	// given signature.
+	SignatureValues(sig []byte) (r, s, v *big.Int, err error)
+}
+
+// HomesteadSigner implements the legacy, non-chain-ID-aware signing scheme.
+// It is kept around so that checks issued before the chain-ID upgrade
+// remain redeemable.
+type HomesteadSigner struct{}
+
+func (s HomesteadSigner) Hash(check *Check) types.Hash {
+	return rlpHash([]interface{}{
+		check.Nonce,
+		check.DueBlock,
+		check.Coin,
+		check.Value,
+		check.Lock,
+	})
+}
+
+func (s HomesteadSigner) Sender(check *Check) (types.Address, error) {
+	return recoverPlain(s.Hash(check), check.R, check.S, check.V, false)
+}
+
+func (s HomesteadSigner) SignatureValues(sig []byte) (r, s2, v *big.Int, err error) {
+	return signatureValues(sig, 27)
+}
+
+// MinterSigner implements EIP-155-style chain-ID-aware signing: the chain ID
+// is folded into the signed hash and encoded into V, so a check signed for
+// one chain cannot be replayed on another.
+type MinterSigner struct {
+	chainID *big.Int
+}
+
+// NewMinterSigner creates a MinterSigner bound to the given chain ID.
+func NewMinterSigner(chainID *big.Int) MinterSigner {
+	return MinterSigner{chainID: chainID}
+}
+
+func (s MinterSigner) Hash(check *Check) types.Hash {
+	return rlpHash([]interface{}{
+		check.Nonce,
+		check.DueBlock,
+		check.Coin,
+		check.Value,
+		check.Lock,
+		check.ChainID,
+		uint(0),
+		uint(0),
+	})
+}
+
+func (s MinterSigner) Sender(check *Check) (types.Address, error) {
+	if check.ChainID != nil && check.ChainID.Cmp(s.chainID) != 0 {
+		return types.Address{}, errors.New("invalid chain id for signer")
+	}
+
+	v := new(big.Int).Sub(check.V, new(big.Int).Mul(s.chainID, big.NewInt(2)))
+	v.Sub(v, big.NewInt(35))
+
+	return recoverPlain(s.Hash(check), check.R, check.S, v, true)
+}
+
+func (s MinterSigner) SignatureValues(sig []byte) (r, s2, v *big.Int, err error) {
+	r, s2, v, err = signatureValues(sig, 0)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	v.Add(v, new(big.Int).Mul(s.chainID, big.NewInt(2)))
+	v.Add(v, big.NewInt(35))
+
+	return r, s2, v, nil
+}
+
+func signatureValues(sig []byte, vOffset uint64) (r, s, v *big.Int, err error) {
+	if len(sig) != 65 {
+		return nil, nil, nil, fmt.Errorf("wrong size for signature: got %d, want 65", len(sig))
+	}
+
+	r = new(big.Int).SetBytes(sig[:32])
+	s = new(big.Int).SetBytes(sig[32:64])
+	v = new(big.Int).SetUint64(uint64(sig[64]) + vOffset)
+
+	return r, s, v, nil
+}
+
+// LatestSignerForChainID returns the signer that should currently be used to
+// sign checks for the given chain ID. A nil chainID falls back to the legacy
+// HomesteadSigner for backwards compatibility.
+func LatestSignerForChainID(chainID *big.Int) Signer {
+	if chainID == nil {
+		return HomesteadSigner{}
+	}
+
+	return NewMinterSigner(chainID)
+}
+
+// MakeSigner returns a Signer based on the given chain config and block
+// height, so a future hard-fork can flip the default signer without
+// breaking checks signed before the fork.
+func MakeSigner(config ChainConfig, blockHeight uint64) Signer {
+	if config.ChainIDBlock != 0 && blockHeight >= config.ChainIDBlock {
+		return NewMinterSigner(config.ChainID)
+	}
+
+	return HomesteadSigner{}
+}
+
+// ChainConfig describes the subset of consensus parameters the check signer
+// needs to pick the right signing scheme for a given block height.
+type ChainConfig struct {
+	ChainID      *big.Int
+	ChainIDBlock uint64
+}
@@ -0,0 +1,50 @@
+package check
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/crypto"
+)
+
+func TestMinterSignerSignRecoverRoundTrip(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantAddr := crypto.PubkeyToAddress(key.PublicKey)
+
+	chainID := big.NewInt(1)
+	signer := NewMinterSigner(chainID)
+
+	check := &Check{
+		Nonce:    1,
+		DueBlock: 100,
+		Value:    big.NewInt(10),
+		Lock:     big.NewInt(0),
+		ChainID:  chainID,
+	}
+
+	hash := signer.Hash(check)
+
+	sig, err := crypto.Sign(hash[:], key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, s, v, err := signer.SignatureValues(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check.R, check.S, check.V = r, s, v
+
+	gotAddr, err := check.Sender()
+	if err != nil {
+		t.Fatalf("Sender() returned error: %v", err)
+	}
+
+	if gotAddr != wantAddr {
+		t.Fatalf("recovered sender %s, want %s", gotAddr.String(), wantAddr.String())
+	}
+}
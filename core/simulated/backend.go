@@ -0,0 +1,264 @@
+// Package simulated provides an in-memory Minter backend for offline
+// transaction testing and gas estimation, in the spirit of go-ethereum's
+// "simulated backend". It runs transactions against a plain state.StateDB
+// with no Tendermint consensus underneath, so SDK authors and dApp builders
+// can exercise DelegateData.Run, check redemption and coin sell/buy logic
+// without standing up a node.
+package simulated
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/transaction"
+	"github.com/MinterTeam/minter-go-node/core/types"
+)
+
+// ErrGasEstimationFailed is returned by EstimateGas when no gas value up to
+// the block gas limit lets the transaction succeed.
+var ErrGasEstimationFailed = errors.New("gas required exceeds allowance or transaction reverted")
+
+// CallMsg describes a transaction to be simulated without being broadcast.
+type CallMsg struct {
+	From types.Address
+	Tx   *transaction.Transaction
+}
+
+// SimulationReport is the result of dry-running a transaction: the code it
+// would return on broadcast, the human-readable log, and the gas it would
+// use. The `minter tx simulate` CLI command reports this to the caller
+// before deciding whether to actually broadcast.
+type SimulationReport struct {
+	Code    uint32
+	Log     string
+	GasUsed int64
+}
+
+// Simulate dry-runs tx against the current pending state without mutating
+// it, and reports which code.* it would return on broadcast. Unlike
+// CallContract/EstimateGas it never returns an error for a failing
+// transaction: a non-OK code.* is itself the answer being asked for.
+func (b *Backend) Simulate(from types.Address, tx *transaction.Transaction) SimulationReport {
+	snapshot := b.pendingState.Copy()
+	gp := state.NewGasPool(b.blockGasLimit)
+
+	response := tx.Run(transaction.ExecContext{
+		Sender:       from,
+		Tx:           tx,
+		State:        snapshot,
+		IsCheck:      true,
+		RewardPool:   big.NewInt(0),
+		BaseFee:      b.baseFee,
+		GasPool:      gp,
+		CurrentBlock: b.blockHeight,
+	})
+
+	return SimulationReport{
+		Code:    response.Code,
+		Log:     response.Log,
+		GasUsed: response.GasUsed,
+	}
+}
+
+// Backend is an in-memory Minter node: a StateDB backed by a memdb trie,
+// with no networking or consensus, used to try out transactions before
+// broadcasting them.
+type Backend struct {
+	stateDB      *state.StateDB
+	pendingState *state.StateDB
+
+	blockHeight uint64
+	blockTime   time.Time
+
+	gasPool       *state.GasPool
+	blockGasLimit *big.Int
+	baseFee       *big.Int
+
+	// desiredBlockGasLimit is the governance-configured target the block gas
+	// limit drifts towards, one NextBlockGasLimit step per Commit.
+	desiredBlockGasLimit *big.Int
+
+	// lastGasPoolEvent is the most recent block's gas-pool snapshot, standing
+	// in for the ABCI BeginBlock/EndBlock event a real node would emit.
+	lastGasPoolEvent state.GasPoolEvent
+}
+
+// NewBackend creates a Backend seeded with the given genesis state and
+// per-block gas limit. It prunes any pre-existing empty accounts out of the
+// genesis state once on startup, the one-shot migration needed the first
+// time a node starts up after EIP-158 clearing is introduced.
+func NewBackend(genesis *state.StateDB, blockGasLimit *big.Int) *Backend {
+	genesis.PruneEmptyAccountsOnStartup()
+
+	b := &Backend{
+		stateDB:              genesis,
+		blockHeight:          0,
+		blockTime:            time.Unix(0, 0),
+		blockGasLimit:        blockGasLimit,
+		desiredBlockGasLimit: new(big.Int).Set(blockGasLimit),
+		baseFee:              big.NewInt(1),
+	}
+	b.pendingState = b.stateDB.Copy()
+	b.gasPool = state.NewGasPool(blockGasLimit)
+
+	return b
+}
+
+// SendTransaction runs tx against the pending state and, if it succeeds,
+// keeps the resulting state for the next Commit. It rejects tx outright,
+// before ever running it, if tx's own gas limit is bigger than the block
+// could ever hold.
+func (b *Backend) SendTransaction(tx *transaction.Transaction) (transaction.Response, error) {
+	if err := transaction.ValidateGasAgainstBlockLimit(tx, b.blockGasLimit); err != nil {
+		return transaction.Response{}, err
+	}
+
+	sender, err := tx.Sender()
+	if err != nil {
+		return transaction.Response{}, err
+	}
+
+	response := tx.Run(transaction.ExecContext{
+		Sender:       sender,
+		Tx:           tx,
+		State:        b.pendingState,
+		IsCheck:      false,
+		RewardPool:   big.NewInt(0),
+		BaseFee:      b.baseFee,
+		GasPool:      b.gasPool,
+		CurrentBlock: b.blockHeight,
+	})
+	if response.Code != 0 {
+		return response, errors.New(response.Log)
+	}
+
+	return response, nil
+}
+
+// CallContract simulates msg.Tx without mutating any durable state, useful
+// for read-only calls and dry-running a transaction.
+func (b *Backend) CallContract(msg CallMsg) ([]byte, error) {
+	snapshot := b.pendingState.Copy()
+	gp := state.NewGasPool(b.blockGasLimit)
+
+	response := msg.Tx.Run(transaction.ExecContext{
+		Sender:       msg.From,
+		Tx:           msg.Tx,
+		State:        snapshot,
+		IsCheck:      true,
+		RewardPool:   big.NewInt(0),
+		BaseFee:      b.baseFee,
+		GasPool:      gp,
+		CurrentBlock: b.blockHeight,
+	})
+	if response.Code != 0 {
+		return nil, errors.New(response.Log)
+	}
+
+	return json.Marshal(response)
+}
+
+// EstimateGas binary-searches for the smallest gas limit between msg.Tx's
+// declared minimum and the block gas limit for which the transaction still
+// succeeds, re-running it against a fresh copy of the state each time.
+// This relies on StateDB.Copy() being a full, independent deep copy: a
+// shallow copy would let one speculative run's balance/nonce mutations
+// leak into the next, corrupting the search.
+func (b *Backend) EstimateGas(msg CallMsg) (uint64, error) {
+	lo := uint64(msg.Tx.Gas())
+	hi := b.blockGasLimit.Uint64()
+
+	succeeds := func(gas uint64) bool {
+		snapshot := b.pendingState.Copy()
+		gp := state.NewGasPool(new(big.Int).SetUint64(gas))
+
+		response := msg.Tx.Run(transaction.ExecContext{
+			Sender:       msg.From,
+			Tx:           msg.Tx,
+			State:        snapshot,
+			IsCheck:      true,
+			RewardPool:   big.NewInt(0),
+			BaseFee:      b.baseFee,
+			GasPool:      gp,
+			CurrentBlock: b.blockHeight,
+		})
+		return response.Code == 0
+	}
+
+	if !succeeds(hi) {
+		return 0, ErrGasEstimationFailed
+	}
+
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if succeeds(mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	return hi, nil
+}
+
+// Commit seals the pending state as a new synthetic block, running EIP-158
+// account clearing over everything the block touched before persisting it,
+// adjusting the base fee and block gas limit for the next block, snapshotting
+// the gas pool's remaining capacity as the block's GasPoolEvent, and
+// advancing the block height.
+func (b *Backend) Commit() {
+	b.pendingState.CommitBlock()
+
+	gasUsed := new(big.Int).Sub(b.blockGasLimit, b.gasPool.Gas())
+	gasTarget := new(big.Int).Div(b.blockGasLimit, big.NewInt(2))
+	b.baseFee = state.NextBaseFee(b.baseFee, gasUsed, gasTarget)
+
+	b.lastGasPoolEvent = b.gasPool.Event(b.blockGasLimit)
+	b.blockGasLimit = state.NextBlockGasLimit(b.blockGasLimit, b.desiredBlockGasLimit)
+
+	b.stateDB = b.pendingState
+	b.pendingState = b.stateDB.Copy()
+	b.gasPool = state.NewGasPool(b.blockGasLimit)
+	b.blockHeight++
+}
+
+// BaseFee returns the base fee that will apply to the next block, exposed
+// so a JSON-RPC baseFeePerGas-style query has something to read.
+func (b *Backend) BaseFee() *big.Int {
+	return new(big.Int).Set(b.baseFee)
+}
+
+// LastGasPoolEvent returns the gas-pool snapshot captured by the most recent
+// Commit, standing in for the GasPoolEvent a real node's ABCI
+// BeginBlock/EndBlock would emit.
+func (b *Backend) LastGasPoolEvent() state.GasPoolEvent {
+	return b.lastGasPoolEvent
+}
+
+// SetDesiredBlockGasLimit sets the governance-desired block gas limit that
+// Commit steers the actual block gas limit towards, at most
+// 1/1024 of the current limit per block (see state.NextBlockGasLimit).
+func (b *Backend) SetDesiredBlockGasLimit(limit *big.Int) {
+	b.desiredBlockGasLimit = limit
+}
+
+// Rollback discards the pending state, reverting to the last committed
+// block.
+func (b *Backend) Rollback() {
+	b.pendingState = b.stateDB.Copy()
+	b.gasPool = state.NewGasPool(b.blockGasLimit)
+}
+
+// AdjustTime moves the simulated backend's clock forward by d.
+func (b *Backend) AdjustTime(d time.Duration) {
+	b.blockTime = b.blockTime.Add(d)
+}
+
+// AdjustBlockHeight sets the simulated backend's current block height,
+// useful for exercising DueBlock/unlock logic without mining n blocks.
+func (b *Backend) AdjustBlockHeight(n uint64) {
+	b.blockHeight = n
+}
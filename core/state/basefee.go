@@ -0,0 +1,29 @@
+package state
+
+import "math/big"
+
+// MinBaseFee is the floor the dynamic base fee is clamped to so that it can
+// never be driven to (or below) zero by a sequence of underfull blocks.
+var MinBaseFee = big.NewInt(1)
+
+// NextBaseFee computes the base fee for the upcoming block from the parent
+// block's base fee and gas usage, following the same elastic adjustment
+// EIP-1559 uses: blocks above the gas target push the base fee up, blocks
+// below it push the base fee down, both by at most 1/8 per block.
+func NextBaseFee(parentBaseFee, parentGasUsed, gasTarget *big.Int) *big.Int {
+	if gasTarget.Sign() == 0 {
+		return new(big.Int).Set(parentBaseFee)
+	}
+
+	delta := new(big.Int).Sub(parentGasUsed, gasTarget)
+	change := new(big.Int).Mul(parentBaseFee, delta)
+	change.Quo(change, gasTarget)
+	change.Quo(change, big.NewInt(8))
+
+	next := new(big.Int).Add(parentBaseFee, change)
+	if next.Cmp(MinBaseFee) < 0 {
+		return new(big.Int).Set(MinBaseFee)
+	}
+
+	return next
+}
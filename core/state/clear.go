@@ -0,0 +1,39 @@
+package state
+
+import "github.com/MinterTeam/minter-go-node/core/types"
+
+// ClearEmptyObjects implements the "update" phase of EIP-158 account
+// clearing: every object that was touched this block and is now empty is
+// marked deleted so Commit removes it from the trie instead of persisting
+// an empty leaf. StateDB.CommitBlock calls this once per block, after all
+// transactions have run, over its set of dirty state objects; StateDB.Commit
+// does not call it.
+func ClearEmptyObjects(objects map[types.Address]*stateObject) []types.Address {
+	var cleared []types.Address
+
+	for addr, obj := range objects {
+		if obj.touched && obj.empty() {
+			obj.deleted = true
+			cleared = append(cleared, addr)
+		}
+	}
+
+	return cleared
+}
+
+// PruneEmptyAccounts walks every account in the given state and removes the
+// ones that would now be considered empty under EIP-158. It is meant to be
+// run once on startup after the clearing rule is introduced, to sweep out
+// dust accounts created before the rule existed.
+func PruneEmptyAccounts(objects map[types.Address]*stateObject) []types.Address {
+	var pruned []types.Address
+
+	for addr, obj := range objects {
+		if obj.empty() {
+			obj.deleted = true
+			pruned = append(pruned, addr)
+		}
+	}
+
+	return pruned
+}
@@ -0,0 +1,66 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/core/types"
+)
+
+func newEmptyTouchedObject(addr types.Address) *stateObject {
+	return &stateObject{
+		address: addr,
+		data: Account{
+			Balance: Balances{Data: map[types.CoinSymbol]*big.Int{}},
+		},
+		touched: true,
+	}
+}
+
+func TestClearEmptyObjectsRemovesTouchedEmptyAccounts(t *testing.T) {
+	addr := types.Address{0x01}
+	objects := map[types.Address]*stateObject{
+		addr: newEmptyTouchedObject(addr),
+	}
+
+	cleared := ClearEmptyObjects(objects)
+
+	if len(cleared) != 1 || cleared[0] != addr {
+		t.Fatalf("expected %s to be cleared, got %v", addr.String(), cleared)
+	}
+	if !objects[addr].deleted {
+		t.Fatal("cleared object should be marked deleted")
+	}
+}
+
+func TestClearEmptyObjectsSkipsUntouchedAccounts(t *testing.T) {
+	addr := types.Address{0x02}
+	obj := newEmptyTouchedObject(addr)
+	obj.touched = false
+
+	objects := map[types.Address]*stateObject{addr: obj}
+
+	cleared := ClearEmptyObjects(objects)
+
+	if len(cleared) != 0 {
+		t.Fatalf("untouched empty account should not be cleared, got %v", cleared)
+	}
+}
+
+func TestRevertedTouchIsNotCleared(t *testing.T) {
+	addr := types.Address{0x03}
+	obj := newEmptyTouchedObject(addr)
+
+	// Simulate a transaction that touched the (empty) account and then
+	// reverted: the touchChange journal entry undoes the touch.
+	touchChange{account: &obj.address}.revert(&StateDB{
+		stateObjects: map[types.Address]*stateObject{addr: obj},
+	})
+
+	objects := map[types.Address]*stateObject{addr: obj}
+	cleared := ClearEmptyObjects(objects)
+
+	if len(cleared) != 0 {
+		t.Fatalf("a reverted touch should not leave the account eligible for clearing, got %v", cleared)
+	}
+}
@@ -0,0 +1,21 @@
+package state
+
+import "github.com/MinterTeam/minter-go-node/core/types"
+
+// CommitBlock runs the "update" phase of EIP-158 account clearing over this
+// StateDB's dirty objects, marking every touched-and-now-empty account
+// deleted so it is dropped from the trie instead of persisted as an empty
+// leaf. It is called once per block, after all of the block's transactions
+// have run and before the trie is actually committed to disk.
+func (db *StateDB) CommitBlock() []types.Address {
+	return ClearEmptyObjects(db.stateObjects)
+}
+
+// PruneEmptyAccountsOnStartup sweeps every account currently loaded in this
+// StateDB and removes the ones that are empty under EIP-158, regardless of
+// whether they were touched this block. It is meant to run once, the first
+// time a node starts up after the clearing rule is introduced, to clean out
+// dust accounts created before the rule existed.
+func (db *StateDB) PruneEmptyAccountsOnStartup() []types.Address {
+	return PruneEmptyAccounts(db.stateObjects)
+}
@@ -0,0 +1,95 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrGasLimitReached is returned by GasPool.SubGas when a transaction would
+// push the block's cumulative gas usage past its limit.
+var ErrGasLimitReached = errors.New("block gas limit reached")
+
+// GasPool tracks the gas still available in the current block. It is
+// created once per block from the consensus-configured BlockGasLimit and
+// drained as transactions are run, mirroring go-ethereum's GasPool.
+type GasPool big.Int
+
+// NewGasPool creates a GasPool seeded with the block's gas limit.
+func NewGasPool(blockGasLimit *big.Int) *GasPool {
+	gp := GasPool(*new(big.Int).Set(blockGasLimit))
+	return &gp
+}
+
+// AddGas makes gas available for the rest of the block, e.g. to refund gas
+// that was reserved but not spent.
+func (gp *GasPool) AddGas(amount *big.Int) *GasPool {
+	i := (*big.Int)(gp)
+	i.Add(i, amount)
+	return gp
+}
+
+// SubGas deducts the given amount from the pool, failing with
+// ErrGasLimitReached if the block does not have enough gas left.
+func (gp *GasPool) SubGas(amount *big.Int) error {
+	i := (*big.Int)(gp)
+	if i.Cmp(amount) < 0 {
+		return ErrGasLimitReached
+	}
+	i.Sub(i, amount)
+	return nil
+}
+
+// Gas returns the amount of gas remaining in the pool.
+func (gp *GasPool) Gas() *big.Int {
+	return new(big.Int).Set((*big.Int)(gp))
+}
+
+func (gp *GasPool) String() string {
+	return fmt.Sprintf("%d", (*big.Int)(gp))
+}
+
+// GasPoolEvent is a snapshot of a block's gas limit and remaining gas
+// capacity. The simulated backend records one per Commit; it is not
+// attached to ABCI BeginBlock/EndBlock events.
+type GasPoolEvent struct {
+	BlockGasLimit string
+	GasRemaining  string
+}
+
+// Event snapshots the pool's remaining capacity against the block's limit
+// for emission as a GasPoolEvent.
+func (gp *GasPool) Event(blockGasLimit *big.Int) GasPoolEvent {
+	return GasPoolEvent{
+		BlockGasLimit: blockGasLimit.String(),
+		GasRemaining:  gp.Gas().String(),
+	}
+}
+
+// maxGasLimitDeltaDivisor bounds how much the block gas limit may move in either
+// direction in a single block: 1/1024th of the parent limit, the same
+// bound Ethereum uses for its own block gas limit adjustment.
+const maxGasLimitDeltaDivisor = 1024
+
+// NextBlockGasLimit computes the block gas limit for the next block given
+// the parent block's limit and a governance-desired limit, clamping the
+// move to at most parentLimit/1024 (or 1, when that rounds down to zero) so
+// the limit can only drift gradually.
+func NextBlockGasLimit(parentLimit, desiredLimit *big.Int) *big.Int {
+	maxDelta := new(big.Int).Div(parentLimit, big.NewInt(maxGasLimitDeltaDivisor))
+	if maxDelta.Sign() == 0 {
+		maxDelta = big.NewInt(1)
+	}
+
+	delta := new(big.Int).Sub(desiredLimit, parentLimit)
+
+	if delta.CmpAbs(maxDelta) > 0 {
+		if delta.Sign() > 0 {
+			delta = maxDelta
+		} else {
+			delta = new(big.Int).Neg(maxDelta)
+		}
+	}
+
+	return new(big.Int).Add(parentLimit, delta)
+}
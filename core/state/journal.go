@@ -0,0 +1,23 @@
+package state
+
+import "github.com/MinterTeam/minter-go-node/core/types"
+
+// touchChange is a journal entry recording that an account was touched
+// (typically by a zero-value transfer to an already-empty account) so that
+// RevertToSnapshot can undo the touch: without it, a touch made inside a
+// transaction that later reverts would still leave the account eligible for
+// EIP-158 clearing even though the transaction never actually happened.
+type touchChange struct {
+	account *types.Address
+}
+
+func (ch touchChange) revert(db *StateDB) {
+	obj := db.getStateObject(*ch.account)
+	if obj != nil {
+		obj.touched = false
+	}
+}
+
+func (ch touchChange) dirtied() *types.Address {
+	return ch.account
+}
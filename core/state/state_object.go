@@ -32,6 +32,7 @@ import (
 // Account values can be accessed and modified through the object.
 // Finally, call CommitTrie to write the modified storage trie into a database.
 type stateObject struct {
+	db      *StateDB
 	address types.Address
 	data    Account
 
@@ -44,10 +45,27 @@ type stateObject struct {
 	onDirty  func(addr types.Address) // Callback method to mark a state object newly dirty
 }
 
-// empty returns whether the account is considered empty.
+// empty returns whether the account is considered empty, i.e. it has never
+// been used for anything but as the destination of a zero-value transfer.
+// Such accounts are pruned from the trie (EIP-158) once touched. An account
+// with active delegated stake is never empty, even with a zero nonce and
+// balance, since clearing it would orphan its delegation.
 func (s *stateObject) empty() bool {
-	return false
-	//return s.data.Nonce == 0 && s.data.Balance.Sign() == 0
+	if s.data.Nonce != 0 {
+		return false
+	}
+
+	for _, amount := range s.data.Balance.Data {
+		if amount.Sign() != 0 {
+			return false
+		}
+	}
+
+	if s.db != nil && s.db.GetTotalStake(s.address).Sign() != 0 {
+		return false
+	}
+
+	return true
 }
 
 type Balances struct {
@@ -119,6 +137,7 @@ func newObject(db *StateDB, address types.Address, data Account, onDirty func(ad
 	}
 
 	return &stateObject{
+		db:      db,
 		address: address,
 		data:    data,
 		onDirty: onDirty,
@@ -139,6 +158,9 @@ func (self *stateObject) markSuicided() {
 }
 
 func (c *stateObject) touch() {
+	if c.db != nil && !c.touched {
+		c.db.journal.append(touchChange{account: &c.address})
+	}
 	if c.onDirty != nil {
 		c.onDirty(c.Address())
 		c.onDirty = nil
@@ -0,0 +1,50 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/core/types"
+)
+
+func TestStateObjectEmpty(t *testing.T) {
+	coin := types.GetBaseCoin()
+
+	obj := &stateObject{
+		data: Account{
+			Balance: Balances{Data: map[types.CoinSymbol]*big.Int{
+				coin: big.NewInt(0),
+			}},
+		},
+	}
+
+	if !obj.empty() {
+		t.Fatal("account with zero nonce and zero balances should be empty")
+	}
+
+	obj.data.Nonce = 1
+	if obj.empty() {
+		t.Fatal("account with non-zero nonce should not be empty")
+	}
+
+	obj.data.Nonce = 0
+	obj.data.Balance.Data[coin] = big.NewInt(100)
+	if obj.empty() {
+		t.Fatal("account with non-zero balance should not be empty")
+	}
+}
+
+func TestStateObjectAddZeroBalanceTouchesEmptyAccount(t *testing.T) {
+	coin := types.GetBaseCoin()
+
+	touched := false
+	obj := newObject(nil, types.Address{}, Account{}, func(types.Address) {
+		touched = true
+	})
+
+	obj.AddBalance(coin, big.NewInt(0))
+
+	if !touched {
+		t.Fatal("adding a zero balance to an empty account should touch it")
+	}
+}
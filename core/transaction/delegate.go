@@ -5,13 +5,26 @@ import (
 	"fmt"
 	"github.com/MinterTeam/minter-go-node/core/code"
 	"github.com/MinterTeam/minter-go-node/core/commissions"
-	"github.com/MinterTeam/minter-go-node/core/state"
 	"github.com/MinterTeam/minter-go-node/core/types"
 	"github.com/MinterTeam/minter-go-node/formula"
 	"github.com/MinterTeam/minter-go-node/hexutil"
+	"github.com/MinterTeam/minter-go-node/rlp"
 	"math/big"
 )
 
+// TxTypeDelegate is the wire TxType byte identifying a DelegateData payload.
+const TxTypeDelegate byte = 0x02
+
+func init() {
+	Register(TxTypeDelegate, func(raw rlp.RawValue) (TxHandler, error) {
+		var data DelegateData
+		if err := rlp.DecodeBytes(raw, &data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	})
+}
+
 type DelegateData struct {
 	PubKey []byte
 	Coin   types.CoinSymbol
@@ -39,7 +52,17 @@ func (data DelegateData) Gas() int64 {
 	return commissions.DelegateTx
 }
 
-func (data DelegateData) Run(sender types.Address, tx *Transaction, context *state.StateDB, isCheck bool, rewardPool *big.Int, currentBlock uint64) Response {
+func (data DelegateData) Run(ctx ExecContext) Response {
+	sender, tx, context := ctx.Sender, ctx.Tx, ctx.State
+	isCheck, rewardPool, baseFee, gp := ctx.IsCheck, ctx.RewardPool, ctx.BaseFee, ctx.GasPool
+
+	if !isCheck {
+		if err := gp.SubGas(big.NewInt(tx.Gas())); err != nil {
+			return Response{
+				Code: code.BlockGasLimitReached,
+				Log:  err.Error()}
+		}
+	}
 
 	if !context.CoinExists(tx.GasCoin) {
 		return Response{
@@ -53,10 +76,16 @@ func (data DelegateData) Run(sender types.Address, tx *Transaction, context *sta
 			Log:  fmt.Sprintf("Stake should be positive")}
 	}
 
-	commissionInBaseCoin := big.NewInt(0).Mul(tx.GasPrice, big.NewInt(tx.Gas()))
+	gasPrice := TransactionEffectiveGasPrice(tx, baseFee)
+	tip := EffectiveTip(tx, baseFee)
+
+	commissionInBaseCoin := big.NewInt(0).Mul(gasPrice, big.NewInt(tx.Gas()))
 	commissionInBaseCoin.Mul(commissionInBaseCoin, CommissionMultiplier)
 	commission := big.NewInt(0).Set(commissionInBaseCoin)
 
+	tipInBaseCoin := big.NewInt(0).Mul(tip, big.NewInt(tx.Gas()))
+	tipInBaseCoin.Mul(tipInBaseCoin, CommissionMultiplier)
+
 	if tx.GasCoin != types.GetBaseCoin() {
 		coin := context.GetStateCoin(tx.GasCoin)
 
@@ -100,7 +129,10 @@ func (data DelegateData) Run(sender types.Address, tx *Transaction, context *sta
 	}
 
 	if !isCheck {
-		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		// Only the priority tip goes to the block proposer; the base fee
+		// portion of the commission is burned, i.e. simply not credited
+		// anywhere, shrinking total issuance.
+		rewardPool.Add(rewardPool, tipInBaseCoin)
 
 		context.SubBalance(sender, tx.GasCoin, commission)
 		context.SubBalance(sender, data.Coin, data.Stake)
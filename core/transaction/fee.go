@@ -0,0 +1,80 @@
+package transaction
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ErrGasExceedsBlockLimit is returned by ValidateGasAgainstBlockLimit when a
+// transaction's own gas limit is already bigger than the block can ever
+// hold, so it would never be includable.
+var ErrGasExceedsBlockLimit = errors.New("tx gas limit exceeds block gas limit")
+
+// ValidateGasAgainstBlockLimit rejects a transaction outright if its Gas()
+// exceeds blockGasLimit, since no block could ever include it regardless of
+// what else is pending. The simulated backend's SendTransaction calls it;
+// mempool admission does not.
+func ValidateGasAgainstBlockLimit(tx *Transaction, blockGasLimit *big.Int) error {
+	if big.NewInt(tx.Gas()).Cmp(blockGasLimit) > 0 {
+		return ErrGasExceedsBlockLimit
+	}
+
+	return nil
+}
+
+// FeeMode is an optional Transaction RLP field, encoded after Data rather
+// than as a leading prefix byte, that distinguishes its fee scheme. It lets
+// legacy, fixed-price transactions and dynamic-fee transactions coexist on
+// the wire, independently of the transaction's payload TxType.
+type FeeMode byte
+
+const (
+	// FeeModeLegacy is a pre-EIP-1559 transaction: a single GasPrice is paid
+	// in full to the reward pool, with no base fee burn.
+	FeeModeLegacy FeeMode = 0
+
+	// FeeModeDynamic is an EIP-1559-style transaction carrying a MaxFee
+	// (fee cap) and a PriorityTip paid on top of the block's base fee.
+	FeeModeDynamic FeeMode = 1
+)
+
+// TransactionEffectiveGasPrice returns the price per unit of gas the
+// transaction actually pays: for legacy transactions this is the fixed
+// GasPrice, for dynamic-fee transactions it is min(MaxFee, BaseFee+PriorityTip)
+// capped so the sender never pays more than MaxFee.
+func TransactionEffectiveGasPrice(tx *Transaction, baseFee *big.Int) *big.Int {
+	if tx.FeeMode != FeeModeDynamic {
+		return tx.GasPrice
+	}
+
+	price := new(big.Int).Add(baseFee, tx.PriorityTip)
+	if price.Cmp(tx.MaxFee) > 0 {
+		return new(big.Int).Set(tx.MaxFee)
+	}
+
+	return price
+}
+
+// EffectiveTip returns the portion of the effective gas price that goes to
+// the block proposer rather than being burned, used to order the mempool by
+// proposer incentive rather than raw GasPrice.
+func EffectiveTip(tx *Transaction, baseFee *big.Int) *big.Int {
+	if tx.FeeMode != FeeModeDynamic {
+		return tx.GasPrice
+	}
+
+	effective := TransactionEffectiveGasPrice(tx, baseFee)
+	tip := new(big.Int).Sub(effective, baseFee)
+	if tip.Sign() < 0 {
+		return big.NewInt(0)
+	}
+
+	return tip
+}
+
+// LessByEffectiveTip orders two transactions by descending effective tip at
+// the given base fee, for ordering pending transactions by what they pay
+// the proposer rather than by raw GasPrice. No mempool code calls it.
+func LessByEffectiveTip(a, b *Transaction, baseFee *big.Int) bool {
+	return EffectiveTip(a, baseFee).Cmp(EffectiveTip(b, baseFee)) > 0
+}
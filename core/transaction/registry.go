@@ -0,0 +1,113 @@
+package transaction
+
+import (
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+// ExecContext bundles everything a TxHandler needs to run, so new handlers
+// can be added without changing a shared function signature every time the
+// executor needs to thread through another piece of state.
+type ExecContext struct {
+	Sender       types.Address
+	Tx           *Transaction
+	State        *state.StateDB
+	IsCheck      bool
+	RewardPool   *big.Int
+	BaseFee      *big.Int
+	GasPool      *state.GasPool
+	CurrentBlock uint64
+}
+
+// TxHandler is implemented by every transaction payload type (DelegateData,
+// SellCoinData, ...). Registering a TxHandler's decoder lets the executor
+// run it uniformly, without a hard-coded type switch.
+type TxHandler interface {
+	Gas() int64
+	Run(ctx ExecContext) Response
+	MarshalJSON() ([]byte, error)
+	String() string
+}
+
+// HandlerDecoder decodes the RLP-encoded payload of a transaction into its
+// TxHandler.
+type HandlerDecoder func(raw rlp.RawValue) (TxHandler, error)
+
+type registryEntry struct {
+	decode           HandlerDecoder
+	activationHeight uint64
+}
+
+// Registry maps a transaction's TxType byte to the decoder for its payload,
+// optionally gated behind a governance-activated block height so new tx
+// kinds can be soft-activated at an agreed block.
+type Registry struct {
+	entries map[byte]registryEntry
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{entries: make(map[byte]registryEntry)}
+}
+
+// Register adds a decoder for txType, active from genesis.
+func (r *Registry) Register(txType byte, decoder HandlerDecoder) {
+	r.RegisterWithActivation(txType, 0, decoder)
+}
+
+// RegisterWithActivation adds a decoder for txType that only becomes
+// available once the chain reaches activationHeight.
+func (r *Registry) RegisterWithActivation(txType byte, activationHeight uint64, decoder HandlerDecoder) {
+	r.entries[txType] = registryEntry{decode: decoder, activationHeight: activationHeight}
+}
+
+// Lookup returns the decoder registered for txType, if any.
+func (r *Registry) Lookup(txType byte) (HandlerDecoder, bool) {
+	entry, ok := r.entries[txType]
+	if !ok {
+		return nil, false
+	}
+
+	return entry.decode, true
+}
+
+// IsActive reports whether txType has been soft-activated as of blockHeight.
+func (r *Registry) IsActive(txType byte, blockHeight uint64) bool {
+	entry, ok := r.entries[txType]
+	if !ok {
+		return false
+	}
+
+	return blockHeight >= entry.activationHeight
+}
+
+// defaultRegistry is the registry self-registering tx payload types add
+// themselves to from their package init().
+var defaultRegistry = NewRegistry()
+
+// Register adds decoder to the default registry under txType, active from
+// genesis. Third-party forks and testnets can call this from their own
+// init() to add new transaction kinds without patching the executor.
+func Register(txType byte, decoder HandlerDecoder) {
+	defaultRegistry.Register(txType, decoder)
+}
+
+// RegisterWithActivation adds decoder to the default registry under txType,
+// gated behind activationHeight.
+func RegisterWithActivation(txType byte, activationHeight uint64, decoder HandlerDecoder) {
+	defaultRegistry.RegisterWithActivation(txType, activationHeight, decoder)
+}
+
+// Lookup returns the decoder registered for txType in the default registry.
+func Lookup(txType byte) (HandlerDecoder, bool) {
+	return defaultRegistry.Lookup(txType)
+}
+
+// IsActive reports whether txType is soft-activated as of blockHeight in the
+// default registry.
+func IsActive(txType byte, blockHeight uint64) bool {
+	return defaultRegistry.IsActive(txType, blockHeight)
+}
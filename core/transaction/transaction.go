@@ -0,0 +1,136 @@
+package transaction
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/crypto/sha3"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+// ErrInvalidSig is returned by Sender when the transaction's signature
+// fields do not recover to a valid public key.
+var ErrInvalidSig = errors.New("invalid transaction signature")
+
+// Transaction is a signed Minter transaction. Type selects the registered
+// TxHandler that decodes and runs Data; FeeMode selects the fee scheme the
+// sender pays under.
+type Transaction struct {
+	Nonce    uint64
+	GasPrice *big.Int
+	GasCoin  types.CoinSymbol
+	Type     byte
+	Data     rlp.RawValue
+
+	// FeeMode is the wire byte distinguishing legacy fixed-price
+	// transactions from EIP-1559-style dynamic-fee ones; MaxFee and
+	// PriorityTip are only meaningful when FeeMode is FeeModeDynamic.
+	FeeMode     FeeMode  `rlp:"optional"`
+	MaxFee      *big.Int `rlp:"optional"`
+	PriorityTip *big.Int `rlp:"optional"`
+
+	SignatureData []byte
+
+	decodedData TxHandler
+}
+
+// decodeData decodes and caches tx.Data through the handler registered for
+// tx.Type, so repeated calls to Gas()/Run() don't re-decode the payload.
+func (tx *Transaction) decodeData() (TxHandler, error) {
+	if tx.decodedData != nil {
+		return tx.decodedData, nil
+	}
+
+	decode, ok := Lookup(tx.Type)
+	if !ok {
+		return nil, errors.New("unknown transaction type")
+	}
+
+	handler, err := decode(tx.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.decodedData = handler
+	return handler, nil
+}
+
+// Gas returns the gas cost of the transaction's payload.
+func (tx *Transaction) Gas() int64 {
+	handler, err := tx.decodeData()
+	if err != nil {
+		return 0
+	}
+
+	return handler.Gas()
+}
+
+// Hash returns the transaction's signing hash.
+func (tx *Transaction) Hash() types.Hash {
+	return rlpHash([]interface{}{
+		tx.Nonce,
+		tx.GasPrice,
+		tx.GasCoin,
+		tx.Type,
+		tx.Data,
+		tx.FeeMode,
+		tx.MaxFee,
+		tx.PriorityTip,
+	})
+}
+
+// Sender recovers the address that signed the transaction.
+func (tx *Transaction) Sender() (types.Address, error) {
+	if len(tx.SignatureData) != 65 {
+		return types.Address{}, ErrInvalidSig
+	}
+
+	hash := tx.Hash()
+
+	pub, err := crypto.Ecrecover(hash[:], tx.SignatureData)
+	if err != nil {
+		return types.Address{}, err
+	}
+	if len(pub) == 0 || pub[0] != 4 {
+		return types.Address{}, ErrInvalidSig
+	}
+
+	var addr types.Address
+	copy(addr[:], crypto.Keccak256(pub[1:])[12:])
+	return addr, nil
+}
+
+// Run routes the transaction to the TxHandler registered for its Type,
+// instead of a hard-coded type switch, so third-party forks can add new
+// transaction kinds purely by registering a decoder. It rejects the
+// transaction if its Type has not yet been soft-activated as of
+// ctx.CurrentBlock.
+func (tx *Transaction) Run(ctx ExecContext) Response {
+	if !IsActive(tx.Type, ctx.CurrentBlock) {
+		return Response{
+			Code: code.TxTypeNotActivated,
+			Log:  fmt.Sprintf("transaction type %d is not activated at block %d", tx.Type, ctx.CurrentBlock)}
+	}
+
+	handler, err := tx.decodeData()
+	if err != nil {
+		return Response{
+			Code: code.DecodeError,
+			Log:  err.Error()}
+	}
+
+	ctx.Tx = tx
+
+	return handler.Run(ctx)
+}
+
+func rlpHash(x interface{}) (h types.Hash) {
+	hw := sha3.NewKeccak256()
+	rlp.Encode(hw, x)
+	hw.Sum(h[:0])
+	return h
+}